@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
 	ErrAlreadyExists = errors.New("already exists")
+	ErrNotFound      = errors.New("not found")
 )
 
 // Locker is a distributed lock.
@@ -40,6 +45,23 @@ type Options struct {
 
 	// The handler for errors.
 	ErrHandler func(error)
+
+	// Metrics, when set, is notified of job executions, lock contention,
+	// scheduling and job-count changes, so that they can be exported to an
+	// external monitoring system (see the micron/metrics subpackage for a
+	// Prometheus-backed implementation).
+	Metrics Recorder
+
+	// Tracer, when set, is used to open a span around every job execution,
+	// carrying the job name and the scheduled fire time as attributes. The
+	// span is propagated through the context given to Job.Handler.
+	Tracer trace.TracerProvider
+
+	// DefaultChain holds middlewares applied, in order, to every job added
+	// via Add or AddJob, ahead of any job-specific middlewares set via
+	// WithMiddleware. It is equivalent to passing the same middlewares to
+	// Cron.Use right after New.
+	DefaultChain []Middleware
 }
 
 func (o *Options) timezone() string {
@@ -63,35 +85,86 @@ func (o *Options) errHandler() func(error) {
 	return o.ErrHandler
 }
 
+func (o *Options) metrics() Recorder {
+	if o == nil || o.Metrics == nil {
+		return noopRecorder{}
+	}
+	return o.Metrics
+}
+
+func (o *Options) tracer() trace.Tracer {
+	if o == nil || o.Tracer == nil {
+		return trace.NewNoopTracerProvider().Tracer(tracerName)
+	}
+	return o.Tracer.Tracer(tracerName)
+}
+
+func (o *Options) defaultChain() []Middleware {
+	if o == nil {
+		return nil
+	}
+	return o.DefaultChain
+}
+
 type Schedule interface {
 	Next(time.Time) time.Time
 }
 
 type job struct {
 	name     string
-	task     func()
+	expr     string
+	handler  Handler
 	schedule Schedule
 
 	locker Locker
 	opts   *Options
 
+	// ctx is the parent context for every invocation of handler. It is
+	// cancelled when the owning Cron is stopped, so that long-running
+	// handlers have a chance to exit cleanly.
+	ctx context.Context
+
+	// jitter is the maximum random delay added ahead of every fire of the
+	// job, as set via WithJitter. Zero means no jitter.
+	jitter time.Duration
+
 	timer   unsafe.Pointer // type: *time.Timer
 	stopped int32
+	paused  int32
+
+	nextTime unsafe.Pointer // type: *time.Time, the next scheduled fire time
+	lastRun  unsafe.Pointer // type: *runInfo, a snapshot of the most recent execution
+	runCount int64          // the number of times the job has been executed
+}
+
+// runInfo is a snapshot of a job's most recent execution.
+type runInfo struct {
+	time     time.Time
+	duration time.Duration
+	err      error
 }
 
-func newJob(name string, task func(), schedule Schedule, locker Locker, opts *Options) *job {
+func newJob(name, expr string, handler Handler, schedule Schedule, locker Locker, opts *Options, ctx context.Context, jitter time.Duration) *job {
 	return &job{
 		name:     name,
-		task:     task,
+		expr:     expr,
+		handler:  handler,
 		schedule: schedule,
 		locker:   locker,
 		opts:     opts,
+		ctx:      ctx,
+		jitter:   jitter,
 	}
 }
 
 func (j *job) Schedule(prev time.Time) {
 	next := j.schedule.Next(prev)
-	d := time.Until(next)
+	atomic.StorePointer(&j.nextTime, unsafe.Pointer(&next))
+	j.opts.metrics().ObserveNextTime(j.name, next)
+
+	// Adding jitter spreads out the wake-up of replicas that would otherwise
+	// all fire, and contend for the lock, at the exact same instant.
+	d := time.Until(next) + randJitter(j.jitter)
 
 	t := time.AfterFunc(d, func() {
 		if atomic.LoadInt32(&j.stopped) == 1 {
@@ -102,23 +175,144 @@ func (j *job) Schedule(prev time.Time) {
 		// Reschedule the job.
 		j.Schedule(next)
 
-		// Try to obtain the lock.
-		ok, err := j.locker.Lock(j.name, j.opts.lockTTL())
-		if err != nil {
-			j.opts.errHandler()(err)
+		if atomic.LoadInt32(&j.paused) == 1 {
+			// Paused jobs are kept being rescheduled, but skip execution.
+			return
 		}
 
-		if ok {
-			// The lock is obtained successfully, execute the job.
-			j.task()
-		}
+		j.run(next)
 	})
 
 	atomic.StorePointer(&j.timer, unsafe.Pointer(t))
 }
 
+// run obtains the lock for j and, if successful, executes its handler,
+// recording the outcome for later introspection.
+//
+// If j.locker is a RenewableLocker, the lock is kept alive by a background
+// renewal for as long as the handler runs, and the handler's context is
+// cancelled as soon as the lock is lost.
+func (j *job) run(scheduledAt time.Time) {
+	ctx := withScheduledTime(withJobName(j.ctx, j.name), scheduledAt)
+
+	if rl, ok := j.locker.(RenewableLocker); ok {
+		j.runWithRenewal(ctx, rl)
+		return
+	}
+
+	ok, err := j.locker.Lock(j.name, j.opts.lockTTL())
+	if err != nil {
+		j.opts.errHandler()(err)
+	}
+	if !ok {
+		if err == nil {
+			j.opts.metrics().ObserveLockContention(j.name)
+		}
+		return
+	}
+
+	j.execute(ctx)
+}
+
+// runWithRenewal is like run, but keeps the lock alive with rl for as long
+// as the handler runs, cancelling ctx as soon as the lock is lost.
+func (j *job) runWithRenewal(ctx context.Context, rl RenewableLocker) {
+	ok, handle, err := rl.LockWithRenewal(j.name, j.opts.lockTTL())
+	if err != nil {
+		j.opts.errHandler()(err)
+	}
+	if !ok {
+		if err == nil {
+			j.opts.metrics().ObserveLockContention(j.name)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-handle.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	j.execute(ctx)
+
+	handle.Release()
+}
+
+// execute invokes j.handler inside a trace span, recording its outcome and
+// routing any resulting error to Options.ErrHandler.
+func (j *job) execute(ctx context.Context) {
+	scheduledAt, _ := ScheduledTime(ctx)
+
+	ctx, span := j.opts.tracer().Start(ctx, "micron.job",
+		trace.WithAttributes(
+			attribute.String("job.name", j.name),
+			attribute.String("job.scheduled_time", scheduledAt.Format(time.RFC3339)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := j.handler(ctx)
+	duration := time.Since(start)
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	j.recordRun(start, duration, err)
+	j.opts.metrics().ObserveRun(j.name, duration, err)
+
+	if err != nil {
+		j.opts.errHandler()(err)
+	}
+}
+
+// recordRun records the metadata of an execution of j, so that it can later
+// be reported via Cron.Jobs/Cron.Job.
+func (j *job) recordRun(start time.Time, duration time.Duration, err error) {
+	atomic.AddInt64(&j.runCount, 1)
+	atomic.StorePointer(&j.lastRun, unsafe.Pointer(&runInfo{
+		time:     start,
+		duration: duration,
+		err:      err,
+	}))
+}
+
+// info returns a snapshot of j's scheduling and execution metadata.
+func (j *job) info() JobInfo {
+	info := JobInfo{
+		Name:     j.name,
+		Expr:     j.expr,
+		RunCount: atomic.LoadInt64(&j.runCount),
+	}
+
+	if next := (*time.Time)(atomic.LoadPointer(&j.nextTime)); next != nil {
+		info.NextTime = *next
+	}
+
+	if last := (*runInfo)(atomic.LoadPointer(&j.lastRun)); last != nil {
+		info.LastRunTime = last.time
+		info.LastRunDuration = last.duration
+		info.LastErr = last.err
+	}
+
+	return info
+}
+
 func (j *job) Stop() {
 	t := (*time.Timer)(atomic.LoadPointer(&j.timer))
+	if t == nil {
+		// The job has never been scheduled, just mark it as stopped.
+		atomic.StoreInt32(&j.stopped, 1)
+		return
+	}
+
 	// Try to stop the timer.
 	if !t.Stop() {
 		// The job has already been started, set the stopped flag
@@ -157,20 +351,31 @@ type Job struct {
 	// The old-style handler of the job.
 	Task func()
 
-	// The new-style handler of the job.
+	// The new-style, context-aware handler of the job.
 	//
 	// Note that Handler will be preferred if both Task and Handler are specified.
-	Handler func(context.Context) error
+	Handler Handler
+
+	// Options customizes how this job is scheduled and executed, e.g. via
+	// WithJitter or WithMiddleware.
+	Options []JobOption
 }
 
 // Cron is a fault-tolerant job scheduler.
 type Cron struct {
-	jobs map[string]*job
+	mu      sync.RWMutex
+	jobs    map[string]*job
+	started bool
 
 	locker Locker
 	opts   *Options
 
 	location *time.Location
+
+	middlewares []Middleware
+
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // New creates an instance of Cron.
@@ -180,31 +385,66 @@ func New(locker Locker, opts *Options) *Cron {
 		panic(err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &Cron{
-		jobs:     make(map[string]*job),
-		locker:   locker,
-		opts:     opts,
-		location: location,
+		jobs:        make(map[string]*job),
+		locker:      locker,
+		opts:        opts,
+		location:    location,
+		middlewares: append([]Middleware(nil), opts.defaultChain()...),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
+// Use appends middlewares to the chain that will be applied, in order, to
+// every job's Handler when it is added via Add or AddJob. Use must be
+// called before adding the jobs it is meant to affect.
+func (c *Cron) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// buildHandler wraps h with c.middlewares (applied to every job), followed
+// by jobMWs (applied only to the job h belongs to).
+func (c *Cron) buildHandler(h Handler, jobMWs []Middleware) Handler {
+	mws := make([]Middleware, 0, len(c.middlewares)+len(jobMWs))
+	mws = append(mws, c.middlewares...)
+	mws = append(mws, jobMWs...)
+	return chain(h, mws...)
+}
+
 // Add adds a job with the given properties. If name already exists, Add will
 // return ErrAlreadyExists, otherwise it will return nil.
 //
 // Note that the execution interval of the job, which is specified by expr,
 // must be greater than LockTTL.
-func (c *Cron) Add(name, expr string, task func()) error {
+func (c *Cron) Add(name, expr string, task func(), opts ...JobOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if _, ok := c.jobs[name]; ok {
 		return ErrAlreadyExists
 	}
 
-	c.jobs[name] = newJob(
+	settings := newJobSettings(opts)
+
+	j := newJob(
 		name,
-		task,
+		expr,
+		c.buildHandler(taskHandler(task), settings.middlewares),
 		MustParse(expr),
 		c.locker,
 		c.opts,
+		c.ctx,
+		settings.jitter,
 	)
+	c.jobs[name] = j
+	c.opts.metrics().ObserveJobCount(len(c.jobs))
+
+	if c.started {
+		j.Schedule(time.Now().In(c.location))
+	}
 
 	return nil
 }
@@ -212,51 +452,176 @@ func (c *Cron) Add(name, expr string, task func()) error {
 // AddJob adds one or more jobs into Cron c. If the name of any job already
 // exists, AddJob will return ErrAlreadyExists, otherwise it will return nil.
 func (c *Cron) AddJob(job ...Job) error {
-	// Ensure the uniqueness of all job names first.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Ensure the uniqueness of all job names, and that each job has a
+	// handler to run, first.
 	for _, j := range job {
 		if _, ok := c.jobs[j.Name]; ok {
 			return fmt.Errorf("add job %s: %w", j.Name, ErrAlreadyExists)
 		}
+		if j.Task == nil && j.Handler == nil {
+			return fmt.Errorf("add job %s: task or handler must be set", j.Name)
+		}
 	}
 
+	now := time.Now().In(c.location)
+
 	for _, j := range job {
 		// Prefer Handler to Task.
-		task := j.Task
-		// We can't use j.Handler directly in closures, since this will cause
-		// for loop variable bug, see https://github.com/golang/go/discussions/56010.
 		handler := j.Handler
-		if handler != nil {
-			task = func() {
-				if err := handler(context.Background()); err != nil {
-					c.opts.errHandler()(err)
-				}
-			}
+		if handler == nil {
+			handler = taskHandler(j.Task)
 		}
 
-		c.jobs[j.Name] = newJob(
+		settings := newJobSettings(j.Options)
+
+		newJ := newJob(
 			j.Name,
-			task,
+			j.Expr,
+			c.buildHandler(handler, settings.middlewares),
 			MustParse(j.Expr),
 			c.locker,
 			c.opts,
+			c.ctx,
+			settings.jitter,
 		)
+		c.jobs[j.Name] = newJ
+
+		if c.started {
+			newJ.Schedule(now)
+		}
 	}
 
+	c.opts.metrics().ObserveJobCount(len(c.jobs))
+
 	return nil
 }
 
 // Start starts to schedule all jobs.
 func (c *Cron) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	now := time.Now().In(c.location)
 	for _, job := range c.jobs {
 		job.Schedule(now)
 	}
+	c.started = true
 }
 
 // Stop stops all the jobs. For simplicity now, it does not wait for the inner
 // goroutines (which have been started before) to exit.
+//
+// The context passed to every running Handler is cancelled, so well-behaved
+// long-running handlers get a chance to exit cleanly.
 func (c *Cron) Stop() {
+	c.mu.RLock()
 	for _, job := range c.jobs {
 		job.Stop()
 	}
+	c.mu.RUnlock()
+
+	c.cancel()
+}
+
+// Jobs returns a snapshot of the metadata of every job currently managed
+// by c.
+func (c *Cron) Jobs() []JobInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]JobInfo, 0, len(c.jobs))
+	for _, j := range c.jobs {
+		infos = append(infos, j.info())
+	}
+	return infos
+}
+
+// Job returns the metadata of the job named name. The second return value
+// reports whether the job exists.
+func (c *Cron) Job(name string) (JobInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	j, ok := c.jobs[name]
+	if !ok {
+		return JobInfo{}, false
+	}
+	return j.info(), true
+}
+
+// Remove stops and removes the job named name. If no such job exists,
+// Remove returns ErrNotFound.
+func (c *Cron) Remove(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	j, ok := c.jobs[name]
+	if !ok {
+		return fmt.Errorf("remove job %s: %w", name, ErrNotFound)
+	}
+
+	j.Stop()
+	delete(c.jobs, name)
+	c.opts.metrics().ObserveJobCount(len(c.jobs))
+
+	return nil
+}
+
+// Replace atomically stops the job named name and re-adds it with the
+// given expr, task and opts. If no job named name exists, Replace returns
+// ErrNotFound.
+func (c *Cron) Replace(name, expr string, task func(), opts ...JobOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	old, ok := c.jobs[name]
+	if !ok {
+		return fmt.Errorf("replace job %s: %w", name, ErrNotFound)
+	}
+	old.Stop()
+
+	settings := newJobSettings(opts)
+
+	j := newJob(
+		name,
+		expr,
+		c.buildHandler(taskHandler(task), settings.middlewares),
+		MustParse(expr),
+		c.locker,
+		c.opts,
+		c.ctx,
+		settings.jitter,
+	)
+	c.jobs[name] = j
+
+	if c.started {
+		j.Schedule(time.Now().In(c.location))
+	}
+
+	return nil
+}
+
+// Pause makes the job named name skip execution on its subsequent fires,
+// while keeping it being rescheduled. It is a no-op if no such job exists.
+func (c *Cron) Pause(name string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if j, ok := c.jobs[name]; ok {
+		atomic.StoreInt32(&j.paused, 1)
+	}
+}
+
+// Resume undoes a prior Pause, so the job named name resumes execution on
+// its subsequent fires. It is a no-op if no such job exists.
+func (c *Cron) Resume(name string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if j, ok := c.jobs[name]; ok {
+		atomic.StoreInt32(&j.paused, 0)
+	}
 }
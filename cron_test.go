@@ -0,0 +1,420 @@
+package micron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testLocker is a Locker that always succeeds, for tests that don't care
+// about lock contention.
+type testLocker struct{}
+
+func (testLocker) Lock(job string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func TestCron_Use_OrdersDefaultAheadOfJobMiddleware(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				order = append(order, name)
+				return next(ctx)
+			}
+		}
+	}
+	c.Use(mw("default"))
+
+	h := c.buildHandler(func(context.Context) error {
+		order = append(order, "handler")
+		return nil
+	}, []Middleware{mw("job")})
+
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	want := []string{"default", "job", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("order: got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order: got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestCron_Add_HandlerContextCancelledOnStop(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+
+	err := c.Add("job", "@every 1s", func() {}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			done <- ctx.Err()
+			return ctx.Err()
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	c.Start()
+
+	<-started
+	c.Stop()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("ctx.Err(): got (%v), want (%v)", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler's context was not cancelled by Stop")
+	}
+}
+
+func TestCron_AddJob_PrefersHandlerOverTask(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	var taskCalled, handlerCalled int32
+	err := c.AddJob(Job{
+		Name: "job1",
+		Expr: "@every 1h",
+		Task: func() { atomic.AddInt32(&taskCalled, 1) },
+		Handler: func(context.Context) error {
+			atomic.AddInt32(&handlerCalled, 1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddJob: %v", err)
+	}
+
+	c.jobs["job1"].run(time.Now())
+
+	if atomic.LoadInt32(&taskCalled) != 0 {
+		t.Fatal("Task was called even though Handler was set")
+	}
+	if atomic.LoadInt32(&handlerCalled) != 1 {
+		t.Fatal("Handler was not called")
+	}
+}
+
+func TestCron_ErrHandlerReceivesHandlerError(t *testing.T) {
+	var mu sync.Mutex
+	var gotErr error
+
+	c := New(testLocker{}, &Options{
+		ErrHandler: func(err error) {
+			mu.Lock()
+			gotErr = err
+			mu.Unlock()
+		},
+	})
+
+	err := c.Add("job", "@every 1h", func() {}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			return errBoom
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	c.jobs["job"].run(time.Now())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gotErr != errBoom {
+		t.Fatalf("ErrHandler error: got (%v), want (%v)", gotErr, errBoom)
+	}
+}
+
+func TestCron_Job_NotFound(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	if _, ok := c.Job("nope"); ok {
+		t.Fatal("Job: got ok=true for a job that was never added")
+	}
+}
+
+func TestCron_Jobs_And_Job(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	if err := c.Add("job1", "@every 1h", func() {}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	info, ok := c.Job("job1")
+	if !ok {
+		t.Fatal("Job: got ok=false, want true")
+	}
+	if info.Name != "job1" || info.Expr != "@every 1h" {
+		t.Fatalf("info: got %+v", info)
+	}
+	if info.RunCount != 0 || !info.LastRunTime.IsZero() {
+		t.Fatalf("info: want a freshly-added job to report no runs yet, got %+v", info)
+	}
+
+	c.jobs["job1"].run(time.Now())
+
+	info, ok = c.Job("job1")
+	if !ok {
+		t.Fatal("Job: got ok=false, want true")
+	}
+	if info.RunCount != 1 {
+		t.Fatalf("RunCount: got (%d), want (1)", info.RunCount)
+	}
+	if info.LastRunTime.IsZero() {
+		t.Fatal("LastRunTime: want non-zero after a run")
+	}
+	if info.LastErr != nil {
+		t.Fatalf("LastErr: got (%v), want (nil)", info.LastErr)
+	}
+
+	infos := c.Jobs()
+	if len(infos) != 1 || infos[0].Name != "job1" {
+		t.Fatalf("Jobs: got %+v, want a single entry for job1", infos)
+	}
+}
+
+func TestCron_Job_RecordsLastErr(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	err := c.Add("job1", "@every 1h", func() {}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			return errBoom
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	c.jobs["job1"].run(time.Now())
+
+	info, _ := c.Job("job1")
+	if info.LastErr != errBoom {
+		t.Fatalf("LastErr: got (%v), want (%v)", info.LastErr, errBoom)
+	}
+}
+
+func TestCron_Remove(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	if err := c.Add("job1", "@every 1h", func() {}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := c.Remove("job1"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	if _, ok := c.Job("job1"); ok {
+		t.Fatal("Job: want the job to be gone after Remove")
+	}
+}
+
+func TestCron_Remove_NotFound(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	err := c.Remove("nope")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err: got (%v), want (%v)", err, ErrNotFound)
+	}
+}
+
+func TestCron_Replace(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	if err := c.Add("job1", "@every 1h", func() {}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if err := c.Replace("job1", "@every 2h", func() {}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+
+	info, ok := c.Job("job1")
+	if !ok {
+		t.Fatal("Job: got ok=false after Replace")
+	}
+	if info.Expr != "@every 2h" {
+		t.Fatalf("Expr: got (%s), want (@every 2h)", info.Expr)
+	}
+}
+
+func TestCron_Replace_NotFound(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	err := c.Replace("nope", "@every 1h", func() {})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err: got (%v), want (%v)", err, ErrNotFound)
+	}
+}
+
+// TestCron_Replace_WhileOldJobRunning verifies that Replace does not block
+// on, interfere with, or get confused by an invocation of the old job that
+// is still running when Replace is called.
+func TestCron_Replace_WhileOldJobRunning(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var oldRan int32
+
+	err := c.Add("job", "@every 1h", func() {}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			atomic.AddInt32(&oldRan, 1)
+			close(started)
+			<-release
+			return nil
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	old := c.jobs["job"]
+	go old.run(time.Now())
+	<-started
+
+	var newRan int32
+	if err := c.Replace("job", "@every 1h", func() {
+		atomic.AddInt32(&newRan, 1)
+	}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+
+	// The new job must be in place immediately, without waiting for the
+	// old invocation to finish.
+	if c.jobs["job"] == old {
+		t.Fatal("Replace did not swap in a new job while the old one was still running")
+	}
+
+	close(release)
+
+	if atomic.LoadInt32(&oldRan) != 1 {
+		t.Fatalf("oldRan: got (%d), want (1)", atomic.LoadInt32(&oldRan))
+	}
+
+	c.jobs["job"].run(time.Now())
+	if atomic.LoadInt32(&newRan) != 1 {
+		t.Fatalf("newRan: got (%d), want (1)", atomic.LoadInt32(&newRan))
+	}
+}
+
+func TestCron_Pause_Resume(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	var count int32
+	err := c.Add("job", "@every 1s", func() {
+		atomic.AddInt32(&count, 1)
+	})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	c.Start()
+	defer c.Stop()
+
+	time.Sleep(1300 * time.Millisecond)
+	c.Pause("job")
+	afterPause := atomic.LoadInt32(&count)
+	if afterPause < 1 {
+		t.Fatalf("count before pausing: got (%d), want (>=1)", afterPause)
+	}
+
+	time.Sleep(2 * time.Second)
+	if got := atomic.LoadInt32(&count); got != afterPause {
+		t.Fatalf("count while paused: got (%d), want (%d)", got, afterPause)
+	}
+
+	c.Resume("job")
+	time.Sleep(1300 * time.Millisecond)
+	if got := atomic.LoadInt32(&count); got <= afterPause {
+		t.Fatalf("count after resuming: got (%d), want (>%d)", got, afterPause)
+	}
+}
+
+func TestCron_Pause_Resume_UnknownJob(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	// Both must be no-ops, not panics, for a job that doesn't exist.
+	c.Pause("nope")
+	c.Resume("nope")
+}
+
+// TestJob_Schedule_Paused verifies that a paused job keeps being
+// rescheduled, but skips execution, and resumes executing once unpaused.
+func TestJob_Schedule_Paused(t *testing.T) {
+	var count int32
+	j := newJob(
+		"job", "@every 300ms",
+		func(context.Context) error {
+			atomic.AddInt32(&count, 1)
+			return nil
+		},
+		Every(300*time.Millisecond),
+		testLocker{}, nil, context.Background(), 0,
+	)
+
+	j.Schedule(time.Now())
+	defer j.Stop()
+
+	time.Sleep(1 * time.Second)
+	beforePause := atomic.LoadInt32(&count)
+	if beforePause < 1 {
+		t.Fatalf("count before pausing: got (%d), want (>=1)", beforePause)
+	}
+
+	atomic.StoreInt32(&j.paused, 1)
+	time.Sleep(1 * time.Second)
+	if got := atomic.LoadInt32(&count); got != beforePause {
+		t.Fatalf("count while paused: got (%d), want (%d)", got, beforePause)
+	}
+
+	atomic.StoreInt32(&j.paused, 0)
+	time.Sleep(1 * time.Second)
+	if got := atomic.LoadInt32(&count); got <= beforePause {
+		t.Fatalf("count after resuming: got (%d), want (>%d)", got, beforePause)
+	}
+}
+
+// TestCron_Replace_PreservesOpts verifies that JobOptions such as
+// WithMiddleware, when passed to Replace, are applied to the replacing job
+// instead of being silently dropped.
+func TestCron_Replace_PreservesOpts(t *testing.T) {
+	c := New(testLocker{}, nil)
+
+	if err := c.Add("job", "@every 1h", func() {}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	var mwCalled int32
+	err := c.Replace("job", "@every 2h", func() {}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			atomic.AddInt32(&mwCalled, 1)
+			return next(ctx)
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+
+	c.jobs["job"].run(time.Now())
+
+	if atomic.LoadInt32(&mwCalled) != 1 {
+		t.Fatal("the middleware passed to Replace was not applied to the replaced job")
+	}
+}
@@ -0,0 +1,68 @@
+package micron
+
+import (
+	"context"
+	"time"
+)
+
+// Handler is the context-aware execution model of a job. It supersedes the
+// old-style Job.Task, and is the type that Middleware operates on.
+type Handler func(context.Context) error
+
+// Middleware wraps a Handler with extra behavior (e.g. panic recovery,
+// timeout, retry, logging), producing a new Handler.
+//
+// Middlewares registered via Cron.Use are applied in the order given: the
+// first middleware is the outermost, i.e. it sees the context before, and
+// the returned error after, the ones that follow it.
+type Middleware func(Handler) Handler
+
+// chain wraps h with mws, in order, so that mws[0] is the outermost
+// Handler and mws[len(mws)-1] is the innermost, closest to h.
+func chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// taskHandler adapts an old-style Job.Task into a Handler, so that task and
+// handler based jobs can share the same execution and middleware pipeline.
+func taskHandler(task func()) Handler {
+	return func(context.Context) error {
+		task()
+		return nil
+	}
+}
+
+type jobContextKey int
+
+const (
+	jobNameKey jobContextKey = iota
+	scheduledTimeKey
+)
+
+// withJobName returns a copy of ctx carrying the name of the job being run.
+func withJobName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, jobNameKey, name)
+}
+
+// JobName returns the name of the job that owns ctx, as set by Cron before
+// invoking the job's Handler.
+func JobName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(jobNameKey).(string)
+	return name, ok
+}
+
+// withScheduledTime returns a copy of ctx carrying the time at which the
+// job was scheduled to fire.
+func withScheduledTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, scheduledTimeKey, t)
+}
+
+// ScheduledTime returns the time at which the job that owns ctx was
+// scheduled to fire, as set by Cron before invoking the job's Handler.
+func ScheduledTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(scheduledTimeKey).(time.Time)
+	return t, ok
+}
@@ -0,0 +1,95 @@
+package micron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) error {
+				order = append(order, "before:"+name)
+				err := next(ctx)
+				order = append(order, "after:"+name)
+				return err
+			}
+		}
+	}
+
+	h := chain(func(context.Context) error {
+		order = append(order, "handler")
+		return nil
+	}, mw("a"), mw("b"))
+
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	want := []string{"before:a", "before:b", "handler", "after:b", "after:a"}
+	if len(order) != len(want) {
+		t.Fatalf("order: got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order: got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestChain_NoMiddlewares(t *testing.T) {
+	called := false
+	h := chain(func(context.Context) error {
+		called = true
+		return nil
+	})
+
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !called {
+		t.Fatal("handler was not called")
+	}
+}
+
+func TestTaskHandler(t *testing.T) {
+	called := false
+	h := taskHandler(func() {
+		called = true
+	})
+
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !called {
+		t.Fatal("task was not called")
+	}
+}
+
+func TestJobNameAndScheduledTime(t *testing.T) {
+	ctx := withScheduledTime(withJobName(context.Background(), "job1"), time.Unix(1700000000, 0))
+
+	name, ok := JobName(ctx)
+	if !ok || name != "job1" {
+		t.Fatalf("JobName: got (%q, %v), want (\"job1\", true)", name, ok)
+	}
+
+	st, ok := ScheduledTime(ctx)
+	if !ok || !st.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("ScheduledTime: got (%v, %v), want (1700000000, true)", st, ok)
+	}
+}
+
+func TestJobNameAndScheduledTime_Unset(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := JobName(ctx); ok {
+		t.Fatal("JobName: expected ok=false on an unset context")
+	}
+	if _, ok := ScheduledTime(ctx); ok {
+		t.Fatal("ScheduledTime: expected ok=false on an unset context")
+	}
+}
@@ -0,0 +1,36 @@
+package micron
+
+import "time"
+
+// tracerName identifies the tracer Cron uses to open spans, when
+// Options.Tracer is set.
+const tracerName = "github.com/RussellLuo/micron"
+
+// Recorder receives job execution events, so that an external monitoring
+// system can be kept up to date. A Recorder is set via Options.Metrics.
+//
+// See the micron/metrics subpackage for a Prometheus-backed implementation.
+type Recorder interface {
+	// ObserveRun is called after every execution of a job's handler.
+	ObserveRun(job string, duration time.Duration, err error)
+
+	// ObserveLockContention is called whenever a job fails to obtain its
+	// lock, i.e. another instance is currently running it.
+	ObserveLockContention(job string)
+
+	// ObserveNextTime is called whenever a job is (re)scheduled, reporting
+	// the next time it is due to fire.
+	ObserveNextTime(job string, next time.Time)
+
+	// ObserveJobCount is called whenever the number of jobs managed by
+	// Cron changes.
+	ObserveJobCount(count int)
+}
+
+// noopRecorder is the default Recorder, used when Options.Metrics is unset.
+type noopRecorder struct{}
+
+func (noopRecorder) ObserveRun(job string, duration time.Duration, err error) {}
+func (noopRecorder) ObserveLockContention(job string)                         {}
+func (noopRecorder) ObserveNextTime(job string, next time.Time)               {}
+func (noopRecorder) ObserveJobCount(count int)                                {}
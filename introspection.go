@@ -0,0 +1,30 @@
+package micron
+
+import "time"
+
+// JobInfo is a snapshot of a job's scheduling and execution metadata, as
+// reported by Cron.Jobs and Cron.Job.
+type JobInfo struct {
+	// The unique name of the job.
+	Name string
+
+	// The cron expression of the job.
+	Expr string
+
+	// NextTime is the next time the job is scheduled to fire.
+	NextTime time.Time
+
+	// LastRunTime is the start time of the job's last execution.
+	//
+	// It is the zero value if the job has never been executed.
+	LastRunTime time.Time
+
+	// LastRunDuration is the duration of the job's last execution.
+	LastRunDuration time.Duration
+
+	// LastErr is the error (if any) returned by the job's last execution.
+	LastErr error
+
+	// RunCount is the number of times the job has been executed.
+	RunCount int64
+}
@@ -0,0 +1,65 @@
+package micron
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// JobOption customizes how a single job, added via Add or included in a
+// Job passed to AddJob, is scheduled and executed.
+type JobOption func(*jobSettings)
+
+// jobSettings holds the per-job configuration assembled from a set of
+// JobOption values.
+type jobSettings struct {
+	middlewares []Middleware
+	jitter      time.Duration
+}
+
+func newJobSettings(opts []JobOption) jobSettings {
+	var s jobSettings
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// WithMiddleware returns a JobOption that adds middlewares applying only to
+// the job it is passed to, in addition to (and innermost relative to) any
+// middlewares registered via Cron.Use or Options.DefaultChain.
+func WithMiddleware(middlewares ...Middleware) JobOption {
+	return func(s *jobSettings) {
+		s.middlewares = append(s.middlewares, middlewares...)
+	}
+}
+
+// WithJitter returns a JobOption that adds a uniformly-random delay, up to
+// max, before every execution of the job. It helps avoid a thundering herd
+// of replicas waking their jobs on the same cron boundary, which pairs
+// naturally with a distributed Locker to spread out the resulting lock
+// contention.
+func WithJitter(max time.Duration) JobOption {
+	return func(s *jobSettings) {
+		s.jitter = max
+	}
+}
+
+// jitterRand is shared by every job that uses WithJitter. It is guarded by
+// jitterMu since rand.Rand is not safe for concurrent use.
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// randJitter returns a random duration in [0, max). It returns 0 if max is
+// not positive.
+func randJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(max)))
+}
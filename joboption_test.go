@@ -0,0 +1,52 @@
+package micron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewJobSettings_WithMiddleware(t *testing.T) {
+	s := newJobSettings([]JobOption{
+		WithMiddleware(passthrough, passthrough),
+	})
+	if len(s.middlewares) != 2 {
+		t.Fatalf("middlewares: got (%d), want (2)", len(s.middlewares))
+	}
+}
+
+func TestNewJobSettings_WithJitter(t *testing.T) {
+	s := newJobSettings([]JobOption{WithJitter(time.Second)})
+	if s.jitter != time.Second {
+		t.Fatalf("jitter: got (%s), want (%s)", s.jitter, time.Second)
+	}
+}
+
+func TestNewJobSettings_NoOpts(t *testing.T) {
+	s := newJobSettings(nil)
+	if s.middlewares != nil || s.jitter != 0 {
+		t.Fatalf("settings: got %+v, want the zero value", s)
+	}
+}
+
+func TestRandJitter_ZeroMax(t *testing.T) {
+	if got := randJitter(0); got != 0 {
+		t.Fatalf("randJitter(0): got (%s), want (0)", got)
+	}
+	if got := randJitter(-time.Second); got != 0 {
+		t.Fatalf("randJitter(negative): got (%s), want (0)", got)
+	}
+}
+
+func TestRandJitter_BoundedByMax(t *testing.T) {
+	max := 50 * time.Millisecond
+	for i := 0; i < 100; i++ {
+		got := randJitter(max)
+		if got < 0 || got >= max {
+			t.Fatalf("randJitter(%s): got (%s), want in [0, %s)", max, got, max)
+		}
+	}
+}
+
+func passthrough(next Handler) Handler {
+	return next
+}
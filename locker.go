@@ -7,6 +7,35 @@ import (
 	"golang.org/x/sync/semaphore"
 )
 
+// RenewableLocker is a Locker that can additionally keep a lock alive for
+// longer than a single TTL, by returning a LockHandle that is kept alive by
+// a background renewal.
+//
+// job.Schedule prefers LockWithRenewal over Lock whenever the configured
+// Locker implements it, so that a long-running handler can't outlive the
+// lock protecting it.
+type RenewableLocker interface {
+	Locker
+
+	// LockWithRenewal behaves like Lock, except that, if the lock is
+	// successfully obtained, it is kept alive by a background renewal
+	// (refreshing it at roughly ttl/3) until the returned LockHandle is
+	// released, and the returned LockHandle reports when the lock is lost.
+	LockWithRenewal(job string, ttl time.Duration) (bool, LockHandle, error)
+}
+
+// LockHandle represents a lock obtained from a RenewableLocker.
+type LockHandle interface {
+	// Done returns a channel that is closed as soon as the lock is lost,
+	// be it because it could no longer be renewed (e.g. Redis became
+	// unreachable, or the lock's key was evicted), or because Release was
+	// called.
+	Done() <-chan struct{}
+
+	// Release releases the lock and stops its background renewal.
+	Release()
+}
+
 // NilLocker implements a fake lock that is always obtainable.
 //
 // It is intended to be used in scenarios where only one instance of Cron is needed.
@@ -0,0 +1,94 @@
+package micron
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is an in-memory JobStore and WatchableJobStore, intended for
+// development, testing, and reconciling jobs created dynamically within
+// the same process.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	jobs     map[string]Job
+	watchers []chan Event
+}
+
+// NewMemoryStore creates an instance of MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		jobs: make(map[string]Job),
+	}
+}
+
+// List implements JobStore.
+func (s *MemoryStore) List(ctx context.Context) ([]Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// Watch implements WatchableJobStore.
+func (s *MemoryStore) Watch(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, 16)
+
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i, w := range s.watchers {
+			if w == ch {
+				s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Put adds or updates the job named j.Name in the store.
+func (s *MemoryStore) Put(j Job) {
+	s.mu.Lock()
+	s.jobs[j.Name] = j
+	s.mu.Unlock()
+
+	s.notify(Event{Type: EventPut, Job: j})
+}
+
+// Delete removes the job named name from the store.
+func (s *MemoryStore) Delete(name string) {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	delete(s.jobs, name)
+	s.mu.Unlock()
+
+	if ok {
+		s.notify(Event{Type: EventDelete, Job: j})
+	}
+}
+
+func (s *MemoryStore) notify(ev Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ch := range s.watchers {
+		select {
+		case ch <- ev:
+		default:
+			// Drop the event for slow watchers rather than blocking Put/Delete.
+		}
+	}
+}
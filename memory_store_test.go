@@ -0,0 +1,82 @@
+package micron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_PutAndList(t *testing.T) {
+	s := NewMemoryStore()
+
+	s.Put(Job{Name: "job1", Expr: "@every 1h"})
+	s.Put(Job{Name: "job2", Expr: "@every 2h"})
+
+	jobs, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("List: got %d jobs, want 2", len(jobs))
+	}
+}
+
+func TestMemoryStore_Delete(t *testing.T) {
+	s := NewMemoryStore()
+	s.Put(Job{Name: "job1", Expr: "@every 1h"})
+	s.Delete("job1")
+
+	jobs, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("List: got %d jobs, want 0", len(jobs))
+	}
+}
+
+func TestMemoryStore_Delete_Unknown(t *testing.T) {
+	s := NewMemoryStore()
+	// Must not notify watchers nor panic for a name that was never put.
+	s.Delete("nope")
+}
+
+func TestMemoryStore_Watch(t *testing.T) {
+	s := NewMemoryStore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := s.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	s.Put(Job{Name: "job1", Expr: "@every 1h"})
+	select {
+	case ev := <-events:
+		if ev.Type != EventPut || ev.Job.Name != "job1" {
+			t.Fatalf("event: got %+v, want a Put of job1", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the Put event")
+	}
+
+	s.Delete("job1")
+	select {
+	case ev := <-events:
+		if ev.Type != EventDelete || ev.Job.Name != "job1" {
+			t.Fatalf("event: got %+v, want a Delete of job1", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the Delete event")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("events channel was not closed after ctx was cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the events channel to close")
+	}
+}
@@ -0,0 +1,95 @@
+// Package metrics provides a micron.Recorder that exports job execution
+// metrics to Prometheus.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Recorder implements micron.Recorder by exporting the following
+// Prometheus collectors:
+//
+//   - micron_job_runs_total{job,result}: total number of job executions.
+//   - micron_job_duration_seconds{job}: duration of job executions.
+//   - micron_job_lock_contention_total{job}: total number of times a job's
+//     lock could not be obtained because another instance was already
+//     running it.
+//   - micron_scheduler_jobs: number of jobs currently managed by the
+//     scheduler.
+//   - micron_job_next_fire_timestamp{job}: Unix timestamp of the next time
+//     a job is scheduled to fire.
+type Recorder struct {
+	runsTotal         *prometheus.CounterVec
+	duration          *prometheus.HistogramVec
+	lockContention    *prometheus.CounterVec
+	schedulerJobs     prometheus.Gauge
+	nextFireTimestamp *prometheus.GaugeVec
+}
+
+// NewRecorder creates a Recorder and registers its collectors with reg. If
+// reg is nil, prometheus.DefaultRegisterer is used.
+func NewRecorder(reg prometheus.Registerer) *Recorder {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	r := &Recorder{
+		runsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "micron_job_runs_total",
+			Help: "Total number of job executions, by result.",
+		}, []string{"job", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "micron_job_duration_seconds",
+			Help: "Duration of job executions, in seconds.",
+		}, []string{"job"}),
+		lockContention: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "micron_job_lock_contention_total",
+			Help: "Total number of times a job's lock could not be obtained because another instance was already running it.",
+		}, []string{"job"}),
+		schedulerJobs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "micron_scheduler_jobs",
+			Help: "Number of jobs currently managed by the scheduler.",
+		}),
+		nextFireTimestamp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "micron_job_next_fire_timestamp",
+			Help: "Unix timestamp of the next time a job is scheduled to fire.",
+		}, []string{"job"}),
+	}
+
+	reg.MustRegister(
+		r.runsTotal,
+		r.duration,
+		r.lockContention,
+		r.schedulerJobs,
+		r.nextFireTimestamp,
+	)
+
+	return r
+}
+
+// ObserveRun implements micron.Recorder.
+func (r *Recorder) ObserveRun(job string, duration time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	r.runsTotal.WithLabelValues(job, result).Inc()
+	r.duration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+// ObserveLockContention implements micron.Recorder.
+func (r *Recorder) ObserveLockContention(job string) {
+	r.lockContention.WithLabelValues(job).Inc()
+}
+
+// ObserveNextTime implements micron.Recorder.
+func (r *Recorder) ObserveNextTime(job string, next time.Time) {
+	r.nextFireTimestamp.WithLabelValues(job).Set(float64(next.Unix()))
+}
+
+// ObserveJobCount implements micron.Recorder.
+func (r *Recorder) ObserveJobCount(count int) {
+	r.schedulerJobs.Set(float64(count))
+}
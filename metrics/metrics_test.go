@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	if err := g.Write(&m); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return m.GetGauge().GetValue()
+}
+
+func TestRecorder_ObserveRun(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r := NewRecorder(reg)
+
+	r.ObserveRun("job1", 10*time.Millisecond, nil)
+	r.ObserveRun("job1", 10*time.Millisecond, errors.New("boom"))
+
+	gotOK := counterValue(t, r.runsTotal.WithLabelValues("job1", "ok"))
+	if gotOK != 1 {
+		t.Fatalf("runsTotal(ok): got (%v), want (1)", gotOK)
+	}
+
+	gotErr := counterValue(t, r.runsTotal.WithLabelValues("job1", "error"))
+	if gotErr != 1 {
+		t.Fatalf("runsTotal(error): got (%v), want (1)", gotErr)
+	}
+}
+
+func TestRecorder_ObserveLockContention(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r := NewRecorder(reg)
+
+	r.ObserveLockContention("job1")
+	r.ObserveLockContention("job1")
+
+	got := counterValue(t, r.lockContention.WithLabelValues("job1"))
+	if got != 2 {
+		t.Fatalf("lockContention: got (%v), want (2)", got)
+	}
+}
+
+func TestRecorder_ObserveNextTime(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r := NewRecorder(reg)
+
+	next := time.Now().Add(time.Minute)
+	r.ObserveNextTime("job1", next)
+
+	got := gaugeValue(t, r.nextFireTimestamp.WithLabelValues("job1"))
+	want := float64(next.Unix())
+	if got != want {
+		t.Fatalf("nextFireTimestamp: got (%v), want (%v)", got, want)
+	}
+}
+
+func TestRecorder_ObserveJobCount(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r := NewRecorder(reg)
+
+	r.ObserveJobCount(3)
+
+	got := gaugeValue(t, r.schedulerJobs)
+	if got != 3 {
+		t.Fatalf("schedulerJobs: got (%v), want (3)", got)
+	}
+}
@@ -0,0 +1,159 @@
+package micron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Logger is the minimal logging interface required by SkipIfStillRunning
+// and DelayIfStillRunning. It is satisfied by *log.Logger from the
+// standard library.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// StructuredLogger is the logging interface required by Logging. Unlike
+// Logger, it takes a message followed by alternating key-value pairs
+// instead of a format string, so a structured logging pipeline (e.g.
+// zap's SugaredLogger, slog.Logger) can consume the job name, duration
+// and error as separate fields instead of having to re-parse them out of
+// free text.
+type StructuredLogger interface {
+	Log(msg string, keyvals ...interface{})
+}
+
+// Recover returns a Middleware that recovers from panics raised by the
+// wrapped Handler and turns them into an error, so that a misbehaving job
+// can't take down the whole process. The resulting error is returned like
+// any other Handler error, which means it ends up being passed to
+// Options.ErrHandler.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic recovered: %v", r)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// Timeout returns a Middleware that cancels the context passed to the
+// wrapped Handler after d elapses, so a job can't run forever.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx)
+		}
+	}
+}
+
+// Retry returns a Middleware that retries the wrapped Handler up to
+// maxRetries times, on top of the initial attempt, whenever it returns an
+// error. The delay between attempts starts at initialBackoff and doubles
+// after every failed attempt. Retrying stops early if ctx is done.
+func Retry(maxRetries int, initialBackoff time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			backoff := initialBackoff
+
+			var err error
+			for attempt := 0; attempt <= maxRetries; attempt++ {
+				if err = next(ctx); err == nil {
+					return nil
+				}
+
+				if attempt == maxRetries {
+					break
+				}
+
+				timer := time.NewTimer(backoff)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+				backoff *= 2
+			}
+
+			return err
+		}
+	}
+}
+
+// SkipIfStillRunning returns a Middleware that drops an invocation of the
+// wrapped Handler if the previous invocation is still running, logging the
+// skip via logger. It is meant to be used as a per-job JobOption, via
+// WithMiddleware, since the "still running" state it tracks is specific to
+// the Handler it wraps.
+func SkipIfStillRunning(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		var running int32
+		return func(ctx context.Context) error {
+			if !atomic.CompareAndSwapInt32(&running, 0, 1) {
+				name, _ := JobName(ctx)
+				logger.Printf("job %q skipped: still running", name)
+				return nil
+			}
+			defer atomic.StoreInt32(&running, 0)
+
+			return next(ctx)
+		}
+	}
+}
+
+// DelayIfStillRunning returns a Middleware that, if the previous invocation
+// of the wrapped Handler is still running, blocks the next invocation until
+// it finishes, logging the delay via logger. It is meant to be used as a
+// per-job JobOption, via WithMiddleware, since the mutex it uses to
+// serialize invocations is specific to the Handler it wraps.
+func DelayIfStillRunning(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		var mu sync.Mutex
+		return func(ctx context.Context) error {
+			start := time.Now()
+			mu.Lock()
+			defer mu.Unlock()
+
+			if wait := time.Since(start); wait > time.Millisecond {
+				name, _ := JobName(ctx)
+				logger.Printf("job %q delayed by %s", name, wait)
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// Logging returns a Middleware that logs the start and the end (including
+// the duration and the error, if any) of every invocation of the wrapped
+// Handler, as structured key-value pairs, using logger.
+func Logging(logger StructuredLogger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) error {
+			name, _ := JobName(ctx)
+
+			logger.Log("job started", "job", name)
+			start := time.Now()
+
+			err := next(ctx)
+			duration := time.Since(start)
+
+			if err != nil {
+				logger.Log("job finished", "job", name, "duration", duration, "err", err)
+			} else {
+				logger.Log("job finished", "job", name, "duration", duration)
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package micron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+func TestRecover(t *testing.T) {
+	h := Recover()(func(context.Context) error {
+		panic("oops")
+	})
+
+	err := h(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Fatalf("err: got (%v), want an error mentioning %q", err, "oops")
+	}
+}
+
+func TestRecover_NoPanic(t *testing.T) {
+	h := Recover()(func(context.Context) error {
+		return errBoom
+	})
+
+	if err := h(context.Background()); err != errBoom {
+		t.Fatalf("err: got (%v), want (%v)", err, errBoom)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	h := Timeout(10 * time.Millisecond)(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := h(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err: got (%v), want (%v)", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	attempts := 0
+	h := Retry(3, time.Millisecond)(func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errBoom
+		}
+		return nil
+	})
+
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts: got (%d), want (3)", attempts)
+	}
+}
+
+func TestRetry_ExhaustsRetries(t *testing.T) {
+	attempts := 0
+	h := Retry(2, time.Millisecond)(func(context.Context) error {
+		attempts++
+		return errBoom
+	})
+
+	if err := h(context.Background()); err != errBoom {
+		t.Fatalf("err: got (%v), want (%v)", err, errBoom)
+	}
+	if attempts != 3 { // initial attempt + 2 retries
+		t.Fatalf("attempts: got (%d), want (3)", attempts)
+	}
+}
+
+func TestRetry_StopsEarlyOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	h := Retry(5, time.Hour)(func(context.Context) error {
+		attempts++
+		return errBoom
+	})
+
+	if err := h(ctx); err != errBoom {
+		t.Fatalf("err: got (%v), want (%v)", err, errBoom)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts: got (%d), want (1)", attempts)
+	}
+}
+
+// logEntry is the shape of a call made to fakeStructuredLogger.Log.
+type logEntry struct {
+	msg     string
+	keyvals []interface{}
+}
+
+type fakeStructuredLogger struct {
+	entries []logEntry
+}
+
+func (l *fakeStructuredLogger) Log(msg string, keyvals ...interface{}) {
+	l.entries = append(l.entries, logEntry{msg: msg, keyvals: keyvals})
+}
+
+func TestLogging(t *testing.T) {
+	logger := &fakeStructuredLogger{}
+	ctx := withJobName(context.Background(), "job1")
+
+	h := Logging(logger)(func(context.Context) error {
+		return nil
+	})
+	if err := h(ctx); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if len(logger.entries) != 2 {
+		t.Fatalf("entries: got (%d), want (2)", len(logger.entries))
+	}
+	if logger.entries[0].msg != "job started" {
+		t.Fatalf("entries[0].msg: got (%q), want (%q)", logger.entries[0].msg, "job started")
+	}
+	if logger.entries[1].msg != "job finished" {
+		t.Fatalf("entries[1].msg: got (%q), want (%q)", logger.entries[1].msg, "job finished")
+	}
+}
+
+func TestLogging_Error(t *testing.T) {
+	logger := &fakeStructuredLogger{}
+	ctx := withJobName(context.Background(), "job1")
+
+	h := Logging(logger)(func(context.Context) error {
+		return errBoom
+	})
+	if err := h(ctx); err != errBoom {
+		t.Fatalf("err: got (%v), want (%v)", err, errBoom)
+	}
+
+	last := logger.entries[len(logger.entries)-1]
+	found := false
+	for i := 0; i+1 < len(last.keyvals); i += 2 {
+		if last.keyvals[i] == "err" && last.keyvals[i+1] == errBoom {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("keyvals: got (%v), want an \"err\" key with value (%v)", last.keyvals, errBoom)
+	}
+}
+
+// fakeLogger records every Printf call, for tests of SkipIfStillRunning
+// and DelayIfStillRunning.
+type fakeLogger struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *fakeLogger) Printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, fmt.Sprintf(format, args...))
+}
+
+func (l *fakeLogger) callCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.calls)
+}
+
+func TestSkipIfStillRunning(t *testing.T) {
+	logger := &fakeLogger{}
+	release := make(chan struct{})
+	entered := make(chan struct{}, 1)
+	var runs int32
+
+	h := SkipIfStillRunning(logger)(func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		entered <- struct{}{}
+		<-release
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- h(context.Background()) }()
+	<-entered
+
+	// A second invocation, while the first is still running, must be
+	// skipped rather than run concurrently.
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("skipped invocation returned an error: %v", err)
+	}
+	if logger.callCount() != 1 {
+		t.Fatalf("logger calls: got (%d), want (1)", logger.callCount())
+	}
+	if atomic.LoadInt32(&runs) != 1 {
+		t.Fatalf("runs: got (%d), want (1)", atomic.LoadInt32(&runs))
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// Now that the first invocation finished, a new one must run rather
+	// than being skipped.
+	release = make(chan struct{})
+	close(release)
+	if err := h(context.Background()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	<-entered
+	if atomic.LoadInt32(&runs) != 2 {
+		t.Fatalf("runs: got (%d), want (2)", atomic.LoadInt32(&runs))
+	}
+}
+
+func TestDelayIfStillRunning(t *testing.T) {
+	logger := &fakeLogger{}
+	release := make(chan struct{})
+	entered := make(chan struct{}, 1)
+
+	h := DelayIfStillRunning(logger)(func(ctx context.Context) error {
+		entered <- struct{}{}
+		<-release
+		return nil
+	})
+
+	done1 := make(chan error, 1)
+	go func() { done1 <- h(context.Background()) }()
+	<-entered
+
+	done2 := make(chan error, 1)
+	go func() {
+		done2 <- h(context.Background())
+	}()
+
+	// Give the second invocation a chance to block on the mutex before
+	// releasing the first.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if err := <-done1; err != nil {
+		t.Fatalf("first invocation err: %v", err)
+	}
+	if err := <-done2; err != nil {
+		t.Fatalf("second invocation err: %v", err)
+	}
+	if logger.callCount() != 1 {
+		t.Fatalf("logger calls: got (%d), want (1)", logger.callCount())
+	}
+}
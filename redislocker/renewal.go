@@ -0,0 +1,133 @@
+package redislocker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/RussellLuo/micron"
+)
+
+// RenewingLocker implements micron.RenewableLocker based on Redis (with a
+// single instance). Unlike Locker, a lock obtained via LockWithRenewal is
+// kept alive by a background renewal for as long as it is held, which
+// makes it the correct primitive for jobs whose running time may exceed
+// the lock's ttl.
+type RenewingLocker struct {
+	lockClient *redislock.Client
+}
+
+// NewWithRenewal creates an instance of RenewingLocker.
+func NewWithRenewal(client redis.UniversalClient) *RenewingLocker {
+	return &RenewingLocker{
+		lockClient: redislock.New(client),
+	}
+}
+
+// Lock obtains the lock named job for ttl, without renewal. It implements
+// micron.Locker, for cases where a plain, non-renewed lock suffices.
+func (l *RenewingLocker) Lock(job string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+	if _, err := l.lockClient.Obtain(ctx, job, ttl, nil); err != nil {
+		if err == redislock.ErrNotObtained {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LockWithRenewal implements micron.RenewableLocker.
+func (l *RenewingLocker) LockWithRenewal(job string, ttl time.Duration) (bool, micron.LockHandle, error) {
+	ctx := context.Background()
+
+	lock, err := l.lockClient.Obtain(ctx, job, ttl, nil)
+	if err != nil {
+		if err == redislock.ErrNotObtained {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+
+	h := &lockHandle{
+		lock: lock,
+		ttl:  ttl,
+		done: make(chan struct{}),
+	}
+	go h.renew()
+
+	return true, h, nil
+}
+
+// renewableLock is the subset of *redislock.Lock used by lockHandle. It
+// exists so that the renewal goroutine's lifecycle can be exercised in
+// tests without a live Redis connection.
+type renewableLock interface {
+	Refresh(ctx context.Context, ttl time.Duration, opt *redislock.Options) error
+	Release(ctx context.Context) error
+}
+
+// lockHandle implements micron.LockHandle by periodically refreshing the
+// underlying Redis lock.
+type lockHandle struct {
+	lock renewableLock
+	ttl  time.Duration
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// minRenewInterval is the floor applied to the renewal ticker's interval, so
+// that a TTL configured small enough for ttl/3 to truncate to zero (or
+// below) can't make time.NewTicker panic.
+const minRenewInterval = time.Millisecond
+
+// renewInterval returns the interval at which a lock with the given ttl
+// should be refreshed, which is ttl/3, floored at minRenewInterval.
+func renewInterval(ttl time.Duration) time.Duration {
+	if d := ttl / 3; d >= minRenewInterval {
+		return d
+	}
+	return minRenewInterval
+}
+
+// renew refreshes the lock at renewInterval(h.ttl) until it is released, or
+// until a refresh fails, at which point the lock is considered lost.
+func (h *lockHandle) renew() {
+	ticker := time.NewTicker(renewInterval(h.ttl))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			if err := h.lock.Refresh(context.Background(), h.ttl, nil); err != nil {
+				// The lock could no longer be renewed, e.g. Redis became
+				// unreachable, or the key was evicted by another process.
+				// Either way, the lock is lost.
+				h.markDone()
+				return
+			}
+		}
+	}
+}
+
+func (h *lockHandle) markDone() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+func (h *lockHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+func (h *lockHandle) Release() {
+	h.markDone()
+	_ = h.lock.Release(context.Background())
+}
@@ -0,0 +1,114 @@
+package redislocker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsm/redislock"
+)
+
+// fakeLock is a renewableLock that counts Refresh calls and fails starting
+// from the failAt'th one (1-indexed), without touching a real Redis server.
+type fakeLock struct {
+	failAt    int32
+	refreshes int32
+	released  int32
+}
+
+func (l *fakeLock) Refresh(ctx context.Context, ttl time.Duration, opt *redislock.Options) error {
+	n := atomic.AddInt32(&l.refreshes, 1)
+	if l.failAt > 0 && n >= l.failAt {
+		return errors.New("refresh failed")
+	}
+	return nil
+}
+
+func (l *fakeLock) Release(ctx context.Context) error {
+	atomic.AddInt32(&l.released, 1)
+	return nil
+}
+
+func TestRenewInterval(t *testing.T) {
+	cases := []struct {
+		name string
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{"comfortably above the floor", 300 * time.Millisecond, 100 * time.Millisecond},
+		{"truncates to zero", 2 * time.Nanosecond, minRenewInterval},
+		{"zero ttl", 0, minRenewInterval},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := renewInterval(c.ttl)
+			if got != c.want {
+				t.Fatalf("renewInterval(%s): got (%s), want (%s)", c.ttl, got, c.want)
+			}
+		})
+	}
+}
+
+func TestLockHandle_RenewKeepsLockAlive(t *testing.T) {
+	lock := &fakeLock{}
+	h := &lockHandle{
+		lock: lock,
+		ttl:  30 * time.Millisecond,
+		done: make(chan struct{}),
+	}
+	go h.renew()
+	defer h.Release()
+
+	select {
+	case <-h.Done():
+		t.Fatal("Done closed before any refresh failed")
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	if atomic.LoadInt32(&lock.refreshes) == 0 {
+		t.Fatal("expected at least one Refresh call")
+	}
+}
+
+func TestLockHandle_RenewFailureClosesDone(t *testing.T) {
+	lock := &fakeLock{failAt: 1}
+	h := &lockHandle{
+		lock: lock,
+		ttl:  10 * time.Millisecond,
+		done: make(chan struct{}),
+	}
+	go h.renew()
+
+	select {
+	case <-h.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done was not closed after a failed refresh")
+	}
+}
+
+func TestLockHandle_Release(t *testing.T) {
+	lock := &fakeLock{}
+	h := &lockHandle{
+		lock: lock,
+		ttl:  time.Hour,
+		done: make(chan struct{}),
+	}
+	go h.renew()
+
+	h.Release()
+
+	select {
+	case <-h.Done():
+	default:
+		t.Fatal("Done was not closed by Release")
+	}
+
+	if got := atomic.LoadInt32(&lock.released); got != 1 {
+		t.Fatalf("released: got (%d), want (1)", got)
+	}
+
+	// Release must be safe to call more than once.
+	h.Release()
+}
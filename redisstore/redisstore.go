@@ -0,0 +1,87 @@
+// Package redisstore provides a micron.JobStore backed by a Redis hash.
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/RussellLuo/micron"
+)
+
+// entry is the JSON representation of a job persisted in Redis. Only the
+// declarative part of a micron.Job (its name and cron expression) can be
+// serialized; the executable part (Task/Handler) is not.
+type entry struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+}
+
+// Resolver resolves the Handler that should run the job named name. It is
+// consulted by Store.List to turn the declarative entries held in Redis
+// back into runnable micron.Jobs.
+type Resolver func(name string) (micron.Handler, bool)
+
+// Store implements micron.JobStore based on Redis (with a single instance),
+// storing jobs as the fields of a single hash named key.
+type Store struct {
+	client  redis.UniversalClient
+	key     string
+	resolve Resolver
+}
+
+// New creates an instance of Store, whose jobs are stored under key in
+// Redis. resolve is used to look up the Handler of each job by name; jobs
+// for which resolve returns false are omitted by List.
+func New(client redis.UniversalClient, key string, resolve Resolver) *Store {
+	return &Store{
+		client:  client,
+		key:     key,
+		resolve: resolve,
+	}
+}
+
+// List implements micron.JobStore.
+func (s *Store) List(ctx context.Context) ([]micron.Job, error) {
+	raw, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]micron.Job, 0, len(raw))
+	for name, data := range raw {
+		var e entry
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			return nil, fmt.Errorf("unmarshal job %s: %w", name, err)
+		}
+
+		handler, ok := s.resolve(e.Name)
+		if !ok {
+			continue
+		}
+
+		jobs = append(jobs, micron.Job{
+			Name:    e.Name,
+			Expr:    e.Expr,
+			Handler: handler,
+		})
+	}
+
+	return jobs, nil
+}
+
+// Put adds or updates the cron expression of the job named name.
+func (s *Store) Put(ctx context.Context, name, expr string) error {
+	data, err := json.Marshal(entry{Name: name, Expr: expr})
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, name, data).Err()
+}
+
+// Delete removes the job named name.
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return s.client.HDel(ctx, s.key, name).Err()
+}
@@ -0,0 +1,85 @@
+package redisstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/RussellLuo/micron"
+)
+
+func newTestStore(t *testing.T, resolve Resolver) *Store {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return New(client, "jobs", resolve)
+}
+
+func TestStore_PutAndList(t *testing.T) {
+	handler := func(context.Context) error { return nil }
+	s := newTestStore(t, func(name string) (micron.Handler, bool) {
+		return handler, true
+	})
+
+	ctx := context.Background()
+	if err := s.Put(ctx, "job1", "@every 1h"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	jobs, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "job1" || jobs[0].Expr != "@every 1h" {
+		t.Fatalf("List: got %+v", jobs)
+	}
+	if jobs[0].Handler == nil {
+		t.Fatal("List: want a resolved Handler")
+	}
+}
+
+func TestStore_List_SkipsUnresolvedJobs(t *testing.T) {
+	s := newTestStore(t, func(name string) (micron.Handler, bool) {
+		return nil, false
+	})
+
+	ctx := context.Background()
+	if err := s.Put(ctx, "job1", "@every 1h"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	jobs, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("List: got %d jobs, want 0 for an unresolved handler", len(jobs))
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	s := newTestStore(t, func(name string) (micron.Handler, bool) {
+		return func(context.Context) error { return nil }, true
+	})
+
+	ctx := context.Background()
+	if err := s.Put(ctx, "job1", "@every 1h"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Delete(ctx, "job1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	jobs, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("List: got %d jobs, want 0 after Delete", len(jobs))
+	}
+}
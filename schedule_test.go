@@ -0,0 +1,63 @@
+package micron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Every(t *testing.T) {
+	s, err := Parse("@every 2s")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := s.Next(start)
+	want := start.Add(2 * time.Second)
+	if !got.Equal(want) {
+		t.Fatalf("Next: got (%s), want (%s)", got, want)
+	}
+}
+
+func TestParse_CronExpr(t *testing.T) {
+	s, err := Parse("0 * * * * * *") // at second 0
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	start := time.Date(2023, 1, 1, 0, 0, 30, 0, time.UTC)
+	got := s.Next(start)
+	want := time.Date(2023, 1, 1, 0, 1, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("Next: got (%s), want (%s)", got, want)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("@every not-a-duration"); err == nil {
+		t.Fatal("expected an error for an invalid @every duration")
+	}
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustParse to panic on an invalid expression")
+		}
+	}()
+	MustParse("@every not-a-duration")
+}
+
+func TestEvery_TruncatesToSecond(t *testing.T) {
+	s := Every(1500 * time.Millisecond).(*everySchedule)
+	if s.duration != time.Second {
+		t.Fatalf("duration: got (%s), want (%s)", s.duration, time.Second)
+	}
+}
+
+func TestEvery_ZeroFloorsToOneSecond(t *testing.T) {
+	s := Every(500 * time.Millisecond).(*everySchedule)
+	if s.duration != time.Second {
+		t.Fatalf("duration: got (%s), want (%s)", s.duration, time.Second)
+	}
+}
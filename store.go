@@ -0,0 +1,39 @@
+package micron
+
+import "context"
+
+// JobStore is a pluggable source of Job definitions that Cron can
+// periodically reconcile its in-memory job set against, via SyncEvery.
+type JobStore interface {
+	// List returns the full set of jobs that should currently be scheduled.
+	List(ctx context.Context) ([]Job, error)
+}
+
+// EventType identifies the kind of change reported by an Event.
+type EventType int
+
+const (
+	// EventPut indicates that a job was added or updated.
+	EventPut EventType = iota
+	// EventDelete indicates that a job was removed.
+	EventDelete
+)
+
+// Event reports a single job-level change observed by a WatchableJobStore.
+type Event struct {
+	Type EventType
+	Job  Job
+}
+
+// WatchableJobStore is a JobStore that can additionally stream incremental
+// changes as they happen, instead of only being polled on a fixed interval.
+//
+// SyncEvery uses Watch, when available, to apply changes as soon as they
+// are observed, on top of its regular polling.
+type WatchableJobStore interface {
+	JobStore
+
+	// Watch streams Events reflecting changes to the store's jobs, until
+	// ctx is done, at which point the returned channel is closed.
+	Watch(ctx context.Context) (<-chan Event, error)
+}
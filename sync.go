@@ -0,0 +1,142 @@
+package micron
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SyncEvery starts a background goroutine that periodically reconciles c's
+// in-memory job set against store, adding jobs that are new, removing jobs
+// that no longer exist in store, and replacing jobs whose expression has
+// changed. The first reconciliation happens after the first tick of d.
+//
+// If store also implements WatchableJobStore, its Watch events are applied
+// as they arrive, on top of the periodic reconciliation. The periodic sync
+// and the watch events are serialized against each other, so that a Watch
+// event for a job racing the reconciliation of that same job can't result
+// in it being upserted twice concurrently.
+//
+// The goroutine runs until c is stopped.
+func (c *Cron) SyncEvery(d time.Duration, store JobStore) {
+	var mu sync.Mutex
+
+	go c.syncLoop(d, store, &mu)
+
+	if w, ok := store.(WatchableJobStore); ok {
+		go c.watchLoop(w, &mu)
+	}
+}
+
+func (c *Cron) syncLoop(d time.Duration, store JobStore, mu *sync.Mutex) {
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			mu.Lock()
+			err := c.sync(store)
+			mu.Unlock()
+
+			if err != nil {
+				c.opts.errHandler()(err)
+			}
+		}
+	}
+}
+
+func (c *Cron) watchLoop(store WatchableJobStore, mu *sync.Mutex) {
+	events, err := store.Watch(c.ctx)
+	if err != nil {
+		c.opts.errHandler()(fmt.Errorf("watch jobs: %w", err))
+		return
+	}
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			var err error
+			switch ev.Type {
+			case EventPut:
+				err = c.upsert(ev.Job)
+			case EventDelete:
+				err = c.Remove(ev.Job.Name)
+			}
+			mu.Unlock()
+
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				c.opts.errHandler()(err)
+			}
+		}
+	}
+}
+
+// sync reconciles c's in-memory job set against the jobs currently listed
+// by store.
+func (c *Cron) sync(store JobStore) error {
+	jobs, err := store.List(c.ctx)
+	if err != nil {
+		return fmt.Errorf("sync jobs: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		wanted[j.Name] = true
+	}
+
+	c.mu.RLock()
+	var removed []string
+	for name := range c.jobs {
+		if !wanted[name] {
+			removed = append(removed, name)
+		}
+	}
+	c.mu.RUnlock()
+
+	for _, name := range removed {
+		if err := c.Remove(name); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	for _, j := range jobs {
+		if err := c.upsert(j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsert adds j if it is not currently scheduled, or replaces it if its
+// cron expression has changed. It is a no-op if j is already scheduled
+// with the same expression.
+func (c *Cron) upsert(j Job) error {
+	c.mu.RLock()
+	existing, ok := c.jobs[j.Name]
+	unchanged := ok && existing.expr == j.Expr
+	c.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	if ok {
+		if err := c.Remove(j.Name); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	return c.AddJob(j)
+}
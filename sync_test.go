@@ -0,0 +1,91 @@
+package micron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCron_SyncEvery_ReconcilesAddRemoveAndExprChange(t *testing.T) {
+	c := New(testLocker{}, nil)
+	store := NewMemoryStore()
+
+	store.Put(Job{Name: "job1", Expr: "@every 1h", Task: func() {}})
+	store.Put(Job{Name: "job2", Expr: "@every 1h", Task: func() {}})
+
+	c.SyncEvery(20*time.Millisecond, store)
+	defer c.Stop()
+
+	waitUntil(t, time.Second, func() bool {
+		_, ok1 := c.Job("job1")
+		_, ok2 := c.Job("job2")
+		return ok1 && ok2
+	}, "job1 and job2 to be added")
+
+	store.Delete("job2")
+	waitUntil(t, time.Second, func() bool {
+		_, ok := c.Job("job2")
+		return !ok
+	}, "job2 to be removed")
+
+	store.Put(Job{Name: "job1", Expr: "@every 2h", Task: func() {}})
+	waitUntil(t, time.Second, func() bool {
+		info, ok := c.Job("job1")
+		return ok && info.Expr == "@every 2h"
+	}, "job1's expr to be updated to @every 2h")
+}
+
+// TestCron_SyncEvery_ConcurrentTickAndWatchDoNotRace verifies that a Watch
+// event for a job racing a periodic sync tick for that same job doesn't
+// surface a spurious error (e.g. ErrAlreadyExists from a duplicate
+// AddJob), since the two loops must be serialized against each other.
+func TestCron_SyncEvery_ConcurrentTickAndWatchDoNotRace(t *testing.T) {
+	var errCount int32
+	c := New(testLocker{}, &Options{
+		ErrHandler: func(error) {
+			atomic.AddInt32(&errCount, 1)
+		},
+	})
+	store := NewMemoryStore()
+	store.Put(Job{Name: "job", Expr: "@every 1h", Task: func() {}})
+
+	c.SyncEvery(2*time.Millisecond, store)
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		deadline := time.Now().Add(200 * time.Millisecond)
+		expr := "@every 1h"
+		for time.Now().Before(deadline) {
+			if expr == "@every 1h" {
+				expr = "@every 2h"
+			} else {
+				expr = "@every 1h"
+			}
+			store.Put(Job{Name: "job", Expr: expr, Task: func() {}})
+		}
+	}()
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&errCount); got != 0 {
+		t.Fatalf("errCount: got (%d), want (0); concurrent sync tick and watch event produced spurious errors", got)
+	}
+}
+
+// waitUntil polls cond every few milliseconds until it returns true or
+// timeout elapses, failing the test in the latter case.
+func waitUntil(t *testing.T, timeout time.Duration, cond func() bool, what string) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}